@@ -0,0 +1,61 @@
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Backoff repeats an operation, waiting an exponentially increasing
+// interval between attempts, and gives up once MaxElapsedTime has
+// elapsed since the first attempt.
+type Backoff struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	MaxElapsedTime  time.Duration
+
+	// Jitter randomizes each wait by up to +/- Jitter*interval.
+	// It must be between 0 and 1.
+	Jitter float64
+
+	// start is the time of the first call to Next.
+	start time.Time
+
+	// interval is the base wait before jitter for the next attempt.
+	interval time.Duration
+}
+
+func (r *Backoff) Next() bool {
+	return r.NextCtx(context.Background())
+}
+
+func (r *Backoff) NextCtx(ctx context.Context) bool {
+	if r.start.IsZero() {
+		r.start = time.Now()
+		r.interval = r.InitialInterval
+		return true
+	}
+	if time.Since(r.start) > r.MaxElapsedTime {
+		return false
+	}
+	if !sleepCtx(ctx, r.jitter(r.interval)) {
+		return false
+	}
+	r.interval = time.Duration(float64(r.interval) * r.Multiplier)
+	if r.interval > r.MaxInterval {
+		r.interval = r.MaxInterval
+	}
+	return true
+}
+
+// jitter randomizes d by up to +/- Jitter*d, uniformly.
+func (r *Backoff) jitter(d time.Duration) time.Duration {
+	if r.Jitter <= 0 {
+		return d
+	}
+	delta := r.Jitter * float64(d)
+	min := float64(d) - delta
+	max := float64(d) + delta
+	return time.Duration(min + rand.Float64()*(max-min))
+}
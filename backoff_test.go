@@ -0,0 +1,37 @@
+package retry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/travisjeffery/retry"
+)
+
+func TestBackoffCapsAndStops(t *testing.T) {
+	b := &retry.Backoff{
+		InitialInterval: 2 * time.Millisecond,
+		MaxInterval:     5 * time.Millisecond,
+		Multiplier:      2,
+		MaxElapsedTime:  30 * time.Millisecond,
+		Jitter:          0.5,
+	}
+
+	ft := &fakeT{}
+	var calls int
+	start := time.Now()
+	retry.RunWith(ft, b, func(r *retry.R) {
+		calls++
+		r.Fatalf("fail")
+	})
+	elapsed := time.Since(start)
+
+	if !ft.failed {
+		t.Fatalf("wanted t to be marked failed once MaxElapsedTime passed")
+	}
+	if calls < 2 {
+		t.Fatalf("wanted at least 2 attempts, got %d", calls)
+	}
+	if elapsed > b.MaxElapsedTime+5*b.MaxInterval {
+		t.Fatalf("wanted backoff to stop near MaxElapsedTime, took %s", elapsed)
+	}
+}
@@ -0,0 +1,39 @@
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+// Counter repeats an operation up to Count times and waits between
+// subsequent operations.
+type Counter struct {
+	Count int
+	Wait  time.Duration
+
+	// attempts is the number of attempts already started.
+	attempts int
+}
+
+func (r *Counter) Next() bool {
+	return r.NextCtx(context.Background())
+}
+
+func (r *Counter) NextCtx(ctx context.Context) bool {
+	if r.attempts >= r.Count {
+		return false
+	}
+	if r.attempts > 0 {
+		if !sleepCtx(ctx, r.Wait) {
+			return false
+		}
+	}
+	r.attempts++
+	return true
+}
+
+// ThreeTimes returns a Counter that makes up to 3 attempts, waiting
+// 25ms between attempts.
+func ThreeTimes() *Counter {
+	return &Counter{Count: 3, Wait: 25 * time.Millisecond}
+}
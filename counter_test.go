@@ -0,0 +1,27 @@
+package retry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/travisjeffery/retry"
+)
+
+func TestCounterImmediateFail(t *testing.T) {
+	c := &retry.Counter{Count: 0, Wait: 10 * time.Millisecond}
+	run(t, 0, c.Wait, 0, true, func(ft *fakeT, calls *int) {
+		retry.RunWith(ft, c, func(r *retry.R) {
+			*calls++
+		})
+	})
+}
+
+func TestCounterRetries(t *testing.T) {
+	c := &retry.Counter{Count: 3, Wait: 10 * time.Millisecond}
+	run(t, c.Wait*time.Duration(c.Count), c.Wait, c.Count, true, func(ft *fakeT, calls *int) {
+		retry.RunWith(ft, c, func(r *retry.R) {
+			*calls++
+			r.Fatalf("fail")
+		})
+	})
+}
@@ -0,0 +1,77 @@
+package retry
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoAttempt is returned by Do and RunWatch when r gives up before
+// f (or, for RunWatch, any watch) is ever invoked, e.g.
+// &Counter{Count: 0}. Without it, an exhausted-before-first-attempt
+// retryer would be indistinguishable from success: the loop never
+// assigns a non-nil error, so the zero value and a nil error would
+// otherwise come back looking like f succeeded.
+var ErrNoAttempt = errors.New("retry: retryer gave up before any attempt")
+
+// Do retries f, using r to decide how many times and how long to
+// wait between attempts, until f returns a nil error, r gives up, or
+// ctx is done. It returns the value and error from the last attempt;
+// if ctx is found done before an attempt (even the first), the error
+// is ctx.Err() instead. If r gives up before f is ever invoked, the
+// error is ErrNoAttempt.
+//
+// Unlike Run and RunWith, which are shaped around *testing.T, Do is
+// meant for production code, e.g. retrying an HTTP call or a DB ping,
+// using the same Retryer implementations (Timer, Counter, Backoff).
+func Do[V any](ctx context.Context, r Retryer, f func(ctx context.Context) (V, error)) (V, error) {
+	var val V
+	err := doLoop(ctx, r, func(ctx context.Context) error {
+		v, err := f(ctx)
+		val = v
+		return err
+	})
+	return val, err
+}
+
+// doLoop drives the Retryer-stepping scaffolding shared by Do and
+// RunWatch: advancing r (through NextCtx when r implements
+// CtxRetryer, so a wait step can be cut short by ctx), bailing out on
+// ctx cancellation, and falling back to ErrNoAttempt if r gives up
+// before attempt is ever called. attempt is called once per
+// iteration; doLoop stops and returns nil as soon as it returns nil.
+func doLoop(ctx context.Context, r Retryer, attempt func(ctx context.Context) error) error {
+	cr, hasCtx := r.(CtxRetryer)
+
+	var (
+		err       error
+		attempted bool
+	)
+	for {
+		var ok bool
+		if hasCtx {
+			ok = cr.NextCtx(ctx)
+		} else {
+			ok = r.Next()
+		}
+		if !ok {
+			break
+		}
+		if ctx.Err() != nil {
+			err = ctx.Err()
+			break
+		}
+		attempted = true
+		err = attempt(ctx)
+		if err == nil {
+			return nil
+		}
+	}
+	if err == nil {
+		if !attempted {
+			err = ErrNoAttempt
+		} else {
+			err = ctx.Err()
+		}
+	}
+	return err
+}
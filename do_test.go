@@ -0,0 +1,46 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/travisjeffery/retry"
+)
+
+func TestDoSucceeds(t *testing.T) {
+	c := &retry.Counter{Count: 3, Wait: 5 * time.Millisecond}
+	var calls int
+	val, err := retry.Do(context.Background(), c, func(ctx context.Context) (int, error) {
+		calls++
+		if calls < 2 {
+			return 0, errors.New("not yet")
+		}
+		return 42, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if val != 42 {
+		t.Fatalf("wanted 42, got %d", val)
+	}
+	if calls != 2 {
+		t.Fatalf("wanted 2 calls, got %d", calls)
+	}
+}
+
+func TestDoExhaustedWithoutAttempt(t *testing.T) {
+	c := &retry.Counter{Count: 0}
+	var calls int
+	_, err := retry.Do(context.Background(), c, func(ctx context.Context) (int, error) {
+		calls++
+		return 0, nil
+	})
+	if !errors.Is(err, retry.ErrNoAttempt) {
+		t.Fatalf("wanted ErrNoAttempt, got %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("wanted f never called, got %d calls", calls)
+	}
+}
@@ -2,37 +2,66 @@ package retry
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"runtime"
 	"strings"
-	"sync"
 	"time"
 )
 
 // Run retries the function on a 25ms interval for 2s stopping if it succeeds.
-func Run(t T, f func(r *R)) {
+func Run(t Failer, f func(r *R)) {
+	t.Helper()
 	RunWith(t, &Timer{Timeout: 2 * time.Second, Wait: 25 * time.Millisecond}, f)
 }
 
-func RunWith(t T, r Retryer, f func(r *R)) {
+func RunWith(t Failer, r Retryer, f func(r *R)) {
+	t.Helper()
 	run(t, r, f)
 }
 
-// T is an interface compatible with testing.T.
-type T interface {
+// Failer is an interface compatible with testing.TB.
+type Failer interface {
 	// Log is called for the final test output
 	Log(args ...interface{})
 
 	// FailNow is called when the retrying is abandoned.
 	FailNow()
+
+	// Helper marks the calling function as a test helper function,
+	// so that file:line output points at the caller's call site.
+	Helper()
 }
 
 // Retryer provides an interface for repeating operations
 // until they succeed or an exit condition is met.
 type Retryer interface {
-	// Next returns true if the operation should be repeated.
-	// Otherwise, it calls fail and returns false.
-	Next(fail func()) bool
+	// Next returns true if the operation should be repeated, and
+	// false once the retryer has given up.
+	Next() bool
+}
+
+// CtxRetryer is implemented by retryers whose wait step can be
+// interrupted by context cancellation. Do uses NextCtx in place of
+// Next when a Retryer implements it.
+type CtxRetryer interface {
+	Retryer
+
+	// NextCtx behaves like Next, except the wait step returns early
+	// if ctx is done.
+	NextCtx(ctx context.Context) bool
+}
+
+// sleepCtx sleeps for d, returning false early if ctx is done first.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
 }
 
 // R provides context for the retryer.
@@ -41,9 +70,14 @@ type R struct {
 	output []string
 }
 
+// failNow is the sentinel panic value used by FailNow to unwind the
+// retried function's stack. run recovers it; any other panic value
+// is re-raised.
+var failNow = new(struct{})
+
 func (r *R) FailNow() {
 	r.fail = true
-	runtime.Goexit()
+	panic(failNow)
 }
 
 func (r *R) Fatal(args ...interface{}) {
@@ -69,9 +103,15 @@ func (r *R) Check(err error) {
 }
 
 func (r *R) log(s string) {
+	r.Helper()
 	r.output = append(r.output, decorate(s))
 }
 
+// Helper marks the calling function as a test helper function. It is
+// a no-op, but lets an assertion helper accept either *retry.R or
+// *testing.T through the Failer interface.
+func (r *R) Helper() {}
+
 // Timer repeats an operation for a given amount
 // of time and waits between subsequent operations.
 type Timer struct {
@@ -83,17 +123,19 @@ type Timer struct {
 	stop time.Time
 }
 
-func (r *Timer) Next(fail func()) bool {
+func (r *Timer) Next() bool {
+	return r.NextCtx(context.Background())
+}
+
+func (r *Timer) NextCtx(ctx context.Context) bool {
 	if r.stop.IsZero() {
 		r.stop = time.Now().Add(r.Timeout)
 		return true
 	}
 	if time.Now().After(r.stop) {
-		fail()
 		return false
 	}
-	time.Sleep(r.Wait)
-	return true
+	return sleepCtx(ctx, r.Wait)
 }
 
 func decorate(s string) string {
@@ -129,27 +171,34 @@ func dedup(a []string) string {
 	return b.String()
 }
 
-func run(t T, r Retryer, f func(r *R)) {
+// run is itself marked as a helper so that, no matter which Retryer
+// drives the loop, the eventual t.Log/t.FailNow on exhaustion is
+// attributed to the caller's retry.Run/RunWith call site rather than
+// to run's own frame. Retryer.Next deliberately has no access to t:
+// if it called t.Helper()/t.FailNow() directly, the reported file:line
+// would depend on which Retryer happened to be in use.
+func run(t Failer, r Retryer, f func(r *R)) {
+	t.Helper()
 	rr := &R{}
-	fail := func() {
-		out := dedup(rr.output)
-		if out != "" {
-			t.Log(out)
-		}
-		t.FailNow()
-	}
-	for r.Next(fail) {
-		var wg sync.WaitGroup
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
+	for r.Next() {
+		func() {
+			defer func() {
+				if e := recover(); e != nil && e != failNow {
+					panic(e)
+				}
+			}()
 			f(rr)
 		}()
-		wg.Wait()
 		if rr.fail {
 			rr.fail = false
 			continue
 		}
-		break
+		return
+	}
+	t.Helper()
+	out := dedup(rr.output)
+	if out != "" {
+		t.Log(out)
 	}
+	t.FailNow()
 }
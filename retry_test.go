@@ -86,3 +86,5 @@ func (t *fakeT) Log(args ...interface{}) {}
 func (t *fakeT) FailNow() {
 	t.failed = true
 }
+
+func (t *fakeT) Helper() {}
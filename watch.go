@@ -0,0 +1,118 @@
+package retry
+
+import (
+	"context"
+	"reflect"
+)
+
+// WatchFunc is invoked when its associated channel yields a value.
+// It shares the retried operation's outcome: a non-nil error counts
+// as a failed attempt, subject to the same Retryer policy as f.
+type WatchFunc func(ctx context.Context) error
+
+// watch pairs a named channel with the function to run when it
+// fires. name is made available to fn through WatchName, e.g. to log
+// which watch woke up an iteration.
+type watch struct {
+	name string
+	ch   reflect.Value
+	fn   WatchFunc
+}
+
+// WatchOption configures RunWatch; see WithWatch.
+type WatchOption func(*watchConfig)
+
+type watchConfig struct {
+	watches []watch
+}
+
+// watchNameKey is the context key under which RunWatch stores a
+// firing watch's name, retrievable through WatchName.
+type watchNameKey struct{}
+
+// WatchName returns the name passed to WithWatch for the watch whose
+// fn is currently running, if ctx was passed down from that fn.
+func WatchName(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(watchNameKey{}).(string)
+	return name, ok
+}
+
+// WithWatch registers a channel for RunWatch. Whenever a value
+// arrives on ch, fn runs in place of that iteration's f, e.g. "retry
+// this operation, but also re-run it when a config-reload or
+// leadership-change event fires." name is recoverable from fn's ctx
+// via WatchName, e.g. for logging which watch fired.
+//
+// A closed ch is never treated as firing, so RunWatch keeps running f
+// as normal rather than looping on fn once ch is closed.
+//
+// Because fn has no typed return value, a watch-triggered iteration
+// that succeeds makes RunWatch return success with V's zero value (or
+// the value from whichever earlier call to f last ran), not a fresh
+// result from f. Only use WithWatch when the caller doesn't depend on
+// the returned value reflecting the watch-triggered run.
+func WithWatch[V any](name string, ch <-chan V, fn WatchFunc) WatchOption {
+	return func(c *watchConfig) {
+		c.watches = append(c.watches, watch{name: name, ch: reflect.ValueOf(ch), fn: fn})
+	}
+}
+
+// RunWatch is like Do, but also reacts to channels registered with
+// WithWatch. Each retry iteration first checks, without blocking,
+// whether a watched channel has a value ready; if so, that watch's
+// fn runs for the iteration instead of f. Because a watch-triggered
+// run still consumes one of r's iterations, a storm of channel
+// events is paced by the same backoff as ordinary failures rather
+// than bypassing it.
+func RunWatch[V any](ctx context.Context, r Retryer, f func(ctx context.Context) (V, error), opts ...WatchOption) (V, error) {
+	var cfg watchConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if len(cfg.watches) == 0 {
+		return Do(ctx, r, f)
+	}
+
+	cases := make([]reflect.SelectCase, len(cfg.watches))
+	for i, w := range cfg.watches {
+		cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: w.ch}
+	}
+
+	var val V
+	err := doLoop(ctx, r, func(ctx context.Context) error {
+		if i, fired := pollWatches(cases); fired {
+			w := cfg.watches[i]
+			return w.fn(context.WithValue(ctx, watchNameKey{}, w.name))
+		}
+		v, err := f(ctx)
+		val = v
+		return err
+	})
+	return val, err
+}
+
+// pollWatches does a non-blocking reflect.Select over cases,
+// returning the index of the case that fired, if any. A closed
+// channel is always ready to receive, so it is never reported as
+// fired here; otherwise, once any watched channel was closed,
+// RunWatch would call that watch's fn on every iteration instead of
+// ever running f again. A closed channel's case is disabled in cases
+// and the select is retried, so it cannot hide a value that is ready
+// on another watched channel.
+func pollWatches(cases []reflect.SelectCase) (int, bool) {
+	withDefault := make([]reflect.SelectCase, len(cases)+1)
+	copy(withDefault, cases)
+	withDefault[len(cases)] = reflect.SelectCase{Dir: reflect.SelectDefault}
+
+	for {
+		i, _, ok := reflect.Select(withDefault)
+		if i == len(cases) {
+			return 0, false
+		}
+		if ok {
+			return i, true
+		}
+		cases[i].Chan = reflect.Value{}
+		withDefault[i].Chan = reflect.Value{}
+	}
+}
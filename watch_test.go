@@ -0,0 +1,65 @@
+package retry_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/travisjeffery/retry"
+)
+
+func TestRunWatchFires(t *testing.T) {
+	ch := make(chan struct{}, 1)
+	ch <- struct{}{}
+
+	c := &retry.Counter{Count: 3, Wait: 5 * time.Millisecond}
+	var watchCalls, fCalls int
+	var gotName string
+	_, err := retry.RunWatch(context.Background(), c, func(ctx context.Context) (int, error) {
+		fCalls++
+		return 7, nil
+	}, retry.WithWatch("reload", ch, func(ctx context.Context) error {
+		watchCalls++
+		gotName, _ = retry.WatchName(ctx)
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if watchCalls != 1 {
+		t.Fatalf("wanted the watch to fire once, got %d", watchCalls)
+	}
+	if fCalls != 0 {
+		t.Fatalf("wanted f not to run once the watch fired, got %d calls", fCalls)
+	}
+	if gotName != "reload" {
+		t.Fatalf("wanted WatchName to report %q, got %q", "reload", gotName)
+	}
+}
+
+func TestRunWatchIgnoresClosedChannel(t *testing.T) {
+	ch := make(chan struct{})
+	close(ch)
+
+	c := &retry.Counter{Count: 3, Wait: 5 * time.Millisecond}
+	var watchCalls, fCalls int
+	val, err := retry.RunWatch(context.Background(), c, func(ctx context.Context) (int, error) {
+		fCalls++
+		return 9, nil
+	}, retry.WithWatch("reload", ch, func(ctx context.Context) error {
+		watchCalls++
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if watchCalls != 0 {
+		t.Fatalf("wanted a closed channel never to be treated as fired, got %d watch calls", watchCalls)
+	}
+	if fCalls != 1 {
+		t.Fatalf("wanted f to run normally, got %d calls", fCalls)
+	}
+	if val != 9 {
+		t.Fatalf("wanted 9, got %d", val)
+	}
+}